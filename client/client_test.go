@@ -0,0 +1,91 @@
+package yodlee
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// newTestServer wires up the handful of endpoints GetAccounts needs,
+// returning cobLogins/userLogins/accountCalls counters the caller can
+// inspect after exercising the client.
+func newTestServer(t *testing.T, accounts func(call int) (status int, body string)) (*httptest.Server, *int32, *int32, *int32) {
+	t.Helper()
+	var cobLogins, userLogins, accountCalls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/authenticate/coblogin", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&cobLogins, 1)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"cobrandConversationCredentials": map[string]string{"sessionToken": "cob-token"},
+		})
+	})
+	mux.HandleFunc("/authenticate/login", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&userLogins, 1)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"userContext": map[string]interface{}{
+				"conversationCredentials": map[string]string{"sessionToken": "user-token"},
+			},
+		})
+	})
+	mux.HandleFunc("/jsonsdk/SiteAccountManagement/getAllSiteAccounts", func(w http.ResponseWriter, r *http.Request) {
+		call := int(atomic.AddInt32(&accountCalls, 1))
+		status, body := accounts(call)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	})
+	return httptest.NewServer(mux), &cobLogins, &userLogins, &accountCalls
+}
+
+// TestGetAccounts_ArraySuccessResponse guards against yodleeError mistaking
+// getAllSiteAccounts' array-shaped success body for a failed error-envelope
+// decode.
+func TestGetAccounts_ArraySuccessResponse(t *testing.T) {
+	ts, _, _, _ := newTestServer(t, func(call int) (int, string) {
+		return http.StatusOK, `[{"siteAccountId":1}]`
+	})
+	defer ts.Close()
+
+	c := New("login", "password", WithBaseURL(ts.URL))
+	ctx := context.Background()
+	session, err := c.GetUserSessionToken(ctx, "user", "pass")
+	if err != nil {
+		t.Fatalf("GetUserSessionToken: %v", err)
+	}
+	accounts, err := c.GetAccounts(ctx, session)
+	if err != nil {
+		t.Fatalf("GetAccounts: %v", err)
+	}
+	if len(accounts) != 1 || accounts[0].SiteAccountID != 1 {
+		t.Fatalf("GetAccounts = %+v, want one account with id 1", accounts)
+	}
+}
+
+// TestGetAccounts_RetriesInvalidUserSession guards against withSessionRetry
+// refreshing only the cobrand session: when Yodlee rejects the call with an
+// invalid-session error, the user session must be re-authenticated too
+// before the retry, not just the cobrand session.
+func TestGetAccounts_RetriesInvalidUserSession(t *testing.T) {
+	ts, _, userLogins, _ := newTestServer(t, func(call int) (int, string) {
+		if call == 1 {
+			return http.StatusOK, `{"errorCode":"Y007","errorMessage":"invalid session"}`
+		}
+		return http.StatusOK, `[{"siteAccountId":1}]`
+	})
+	defer ts.Close()
+
+	c := New("login", "password", WithBaseURL(ts.URL))
+	ctx := context.Background()
+	session, err := c.GetUserSessionToken(ctx, "user", "pass")
+	if err != nil {
+		t.Fatalf("GetUserSessionToken: %v", err)
+	}
+	if _, err := c.GetAccounts(ctx, session); err != nil {
+		t.Fatalf("GetAccounts: %v", err)
+	}
+	if got := atomic.LoadInt32(userLogins); got != 2 {
+		t.Errorf("user logins = %d, want 2 (initial + retry refresh)", got)
+	}
+}
@@ -0,0 +1,260 @@
+package yodlee
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Kind classifies an Error by what went wrong, so callers can react without
+// string-matching Yodlee's error codes or messages.
+type Kind int
+
+const (
+	// KindUnknown is an Error whose code is empty or isn't in
+	// errorKindsByCode.
+	KindUnknown Kind = iota
+	// KindAuth covers invalid credentials and invalid/expired sessions
+	// (Yodlee codes Y001, Y002, Y007).
+	KindAuth
+	// KindMFARequired means Yodlee is asking a multi-factor authentication
+	// challenge question before it will continue.
+	KindMFARequired
+	// KindSiteUnavailable means the financial institution Yodlee proxies
+	// to is down or under maintenance, not Yodlee itself.
+	KindSiteUnavailable
+	// KindRateLimited means the caller has exceeded Yodlee's request
+	// quota.
+	KindRateLimited
+	// KindValidation means the request itself was malformed or failed
+	// Yodlee's validation, independent of any site or session state.
+	KindValidation
+	// KindServer means Yodlee reported an internal error on its end.
+	KindServer
+)
+
+// errorKindsByCode maps Yodlee's documented error codes to a Kind. Codes not
+// listed here produce KindUnknown.
+var errorKindsByCode = map[string]Kind{
+	"Y001": KindAuth,
+	"Y002": KindAuth,
+	"Y007": KindAuth,
+	"Y006": KindMFARequired,
+	"Y014": KindMFARequired,
+	"Y800": KindSiteUnavailable,
+	"Y801": KindSiteUnavailable,
+	"Y900": KindRateLimited,
+	"Y010": KindValidation,
+	"Y500": KindServer,
+}
+
+// Error is a structured Yodlee API error, in place of the stringified
+// errors.New(errResp.ErrorMessage()) this package used to return.
+type Error struct {
+	Kind          Kind
+	Code          string
+	Message       string
+	ReferenceCode string
+	Detail        string
+	HTTPStatus    int
+}
+
+func (e *Error) Error() string {
+	s := fmt.Sprintf("yodlee: %s", e.Message)
+	if e.Code != "" {
+		s = fmt.Sprintf("yodlee: %s: %s", e.Code, e.Message)
+	}
+	if e.ReferenceCode != "" {
+		s += fmt.Sprintf(" (reference %s)", e.ReferenceCode)
+	}
+	return s
+}
+
+func newError(code, message, referenceCode, detail string, httpStatus int) *Error {
+	return &Error{
+		Kind:          errorKindsByCode[code],
+		Code:          code,
+		Message:       message,
+		ReferenceCode: referenceCode,
+		Detail:        detail,
+		HTTPStatus:    httpStatus,
+	}
+}
+
+// IsAuthError reports whether err is a yodlee.Error of KindAuth, meaning the
+// credentials or session token used were rejected.
+func IsAuthError(err error) bool { return hasKind(err, KindAuth) }
+
+// IsMFARequired reports whether err is a yodlee.Error of KindMFARequired.
+func IsMFARequired(err error) bool { return hasKind(err, KindMFARequired) }
+
+// IsSiteUnavailable reports whether err is a yodlee.Error of
+// KindSiteUnavailable, meaning the underlying financial institution (not
+// Yodlee) is unreachable.
+func IsSiteUnavailable(err error) bool { return hasKind(err, KindSiteUnavailable) }
+
+// IsRateLimited reports whether err is a yodlee.Error of KindRateLimited.
+func IsRateLimited(err error) bool { return hasKind(err, KindRateLimited) }
+
+// IsValidationError reports whether err is a yodlee.Error of
+// KindValidation.
+func IsValidationError(err error) bool { return hasKind(err, KindValidation) }
+
+// IsServerError reports whether err is a yodlee.Error of KindServer.
+func IsServerError(err error) bool { return hasKind(err, KindServer) }
+
+func hasKind(err error, kind Kind) bool {
+	for _, e := range splitJoined(err) {
+		var yerr *Error
+		if errors.As(e, &yerr) && yerr.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// splitJoined unwraps an error built with errors.Join back into its
+// constituent errors, so the Is* helpers can inspect each one. A non-joined
+// error is returned as a single-element slice.
+func splitJoined(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
+
+// ----------------------------------------------------------------------------------------------------
+// Possible messages:
+// ----------------------------------------------------------------------------------------------------
+
+// YodleeErrResp is implemented by each of Yodlee's error envelope shapes.
+// Yodlee reports errors inside an otherwise successful HTTP response, using
+// one of a handful of shapes that aren't expressible in the OpenAPI response
+// schema, so genclient hands back the raw body and yodleeError checks it
+// here instead.
+type YodleeErrResp interface {
+	IsErrror() bool
+	asError(httpStatus int) error
+}
+
+type ErrorInfo struct {
+	ErrorCode     string `json:"errorCode"`
+	ErrMessage    string `json:"errorMessage"`
+	ErrorDetail   string `json:"errorDetail"`
+	ReferenceCode string `json:"referenceCode"`
+}
+
+func (e *ErrorInfo) IsErrror() bool {
+	return len(e.ErrorCode) > 0 || len(e.ErrMessage) > 0 || len(e.ReferenceCode) > 0 || len(e.ErrorDetail) > 0
+}
+func (e *ErrorInfo) asError(httpStatus int) error {
+	return newError(e.ErrorCode, e.ErrMessage, e.ReferenceCode, e.ErrorDetail, httpStatus)
+}
+
+type MultipleErrorInfo struct {
+	Errors []ErrorInfo `json:"Error"`
+}
+
+func (e *MultipleErrorInfo) IsErrror() bool {
+	if len(e.Errors) == 0 {
+		return false
+	}
+	for _, er := range e.Errors {
+		if er.IsErrror() {
+			return true
+		}
+	}
+	return false
+}
+func (e *MultipleErrorInfo) asError(httpStatus int) error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, er := range e.Errors {
+		if er.IsErrror() {
+			errs = append(errs, er.asError(httpStatus))
+		}
+	}
+	// asError is only ever called after IsErrror reported at least one
+	// entry as an error, so errs is never empty here.
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	// Join rather than collapse to one *Error, so each sub-error keeps its
+	// own Code/Kind/ReferenceCode and the Is* helpers (via splitJoined) can
+	// still recognize e.g. a Y007 invalid-session entry bundled alongside
+	// an unrelated one.
+	return errors.Join(errs...)
+}
+
+type ErrorOccuredMessage struct {
+	ErrorOccurred string `json:"errorOccurred"`
+	ExceptionType string `json:"exceptionType"`
+	ReferenceCode string `json:"referenceCode"`
+	Message       string `json:"message"`
+}
+
+func (e *ErrorOccuredMessage) IsErrror() bool {
+	return e.ErrorOccurred == "true"
+}
+func (e *ErrorOccuredMessage) asError(httpStatus int) error {
+	// e.ExceptionType is a Java class name (e.g.
+	// "com.yodlee...Exception"), not one of Yodlee's documented error
+	// codes, so it belongs in Detail, not Code: Code is what
+	// errorKindsByCode and isInvalidSessionError key off, and a class
+	// name there would just produce KindUnknown.
+	return newError("", e.Message, e.ReferenceCode, e.ExceptionType, httpStatus)
+}
+
+var _ YodleeErrResp = new(ErrorInfo)
+var _ YodleeErrResp = new(MultipleErrorInfo)
+var _ YodleeErrResp = new(ErrorOccuredMessage)
+
+func getYodleeErrorCandidates() []YodleeErrResp {
+	return []YodleeErrResp{
+		new(ErrorInfo),
+		new(MultipleErrorInfo),
+		new(ErrorOccuredMessage),
+	}
+}
+
+// yodleeError turns a genclient call's outcome into the single error this
+// package's methods return. Yodlee reports errors inside an otherwise
+// successful HTTP response, so genclient hands back the raw body and we
+// check it here instead of in genclient itself.
+//
+// Every error envelope Yodlee uses is a JSON object, but several endpoints
+// (e.g. getAllSiteAccounts) decode their success response into something
+// other than a struct (a JSON array), so genclient's typed decode can fail
+// in either direction: a success body won't fit an error-envelope struct,
+// and an error-envelope body won't fit the success type. Check body for an
+// error envelope first, independent of err, and only fall back to err (a
+// transport failure, or a decode error body genuinely doesn't explain) once
+// no envelope matches.
+func yodleeError(body []byte, httpStatus int, err error) error {
+	if len(body) > 0 && !isJSONArray(body) {
+		for _, errResp := range getYodleeErrorCandidates() {
+			if decErr := json.Unmarshal(body, errResp); decErr != nil {
+				break
+			}
+			if errResp.IsErrror() {
+				return errResp.asError(httpStatus)
+			}
+		}
+	}
+	return err
+}
+
+// isJSONArray reports whether body's first non-whitespace byte opens a JSON
+// array, as opposed to the JSON object every error envelope uses.
+func isJSONArray(body []byte) bool {
+	for _, b := range body {
+		switch b {
+		case ' ', '\t', '\n', '\r':
+			continue
+		case '[':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package yodlee
+
+import "testing"
+
+func TestMultipleErrorInfoJoinsSubErrors(t *testing.T) {
+	multi := &MultipleErrorInfo{Errors: []ErrorInfo{
+		{ErrorCode: "Y007", ErrMessage: "invalid session"},
+		{ErrorCode: "Y800", ErrMessage: "site down"},
+	}}
+	err := multi.asError(200)
+	if !IsAuthError(err) {
+		t.Errorf("IsAuthError(%v) = false, want true (Y007 entry should still be found)", err)
+	}
+	if !IsSiteUnavailable(err) {
+		t.Errorf("IsSiteUnavailable(%v) = false, want true (Y800 entry should still be found)", err)
+	}
+}
+
+// TestErrorOccuredMessageLeavesCodeEmpty guards against ExceptionType (a
+// Java class name, not a Yodlee error code) landing in Error.Code, where it
+// would poison errorKindsByCode/isInvalidSessionError lookups instead of
+// just sitting in Detail.
+func TestErrorOccuredMessageLeavesCodeEmpty(t *testing.T) {
+	msg := &ErrorOccuredMessage{
+		ErrorOccurred: "true",
+		ExceptionType: "com.yodlee.core.exception.LoginException",
+		Message:       "login failed",
+	}
+	err := msg.asError(200)
+	yerr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("asError returned %T, want *Error", err)
+	}
+	if yerr.Code != "" {
+		t.Errorf("Code = %q, want empty", yerr.Code)
+	}
+	if yerr.Detail != msg.ExceptionType {
+		t.Errorf("Detail = %q, want %q", yerr.Detail, msg.ExceptionType)
+	}
+	if yerr.Kind != KindUnknown {
+		t.Errorf("Kind = %v, want KindUnknown", yerr.Kind)
+	}
+}
@@ -0,0 +1,96 @@
+package yodlee
+
+import (
+	"net/http"
+	"time"
+)
+
+// Environment selects which Yodlee API deployment a Client talks to.
+type Environment int
+
+const (
+	// EnvProduction is Yodlee's production API. It's the default.
+	EnvProduction Environment = iota
+	// EnvSandbox is Yodlee's sandbox API, used for development and testing.
+	EnvSandbox
+)
+
+const (
+	productionBaseURL = "https://rest.developer.yodlee.com/services/srest/restserver/v1.0"
+	sandboxBaseURL    = "https://developer.yodlee.com/ysl/restserver/v1.0"
+
+	defaultUserAgent = "yodlee-client"
+
+	// defaultSessionRefreshSkew is how far ahead of a session's expiry
+	// Session/UserSession.Token proactively refreshes it.
+	defaultSessionRefreshSkew = 2 * time.Minute
+)
+
+// Logger receives diagnostic output from a Client, in place of the direct
+// fmt.Printf debug prints this package used to emit.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// Option configures a Client created with New.
+type Option func(*Client)
+
+// WithBaseURL points the client at a custom Yodlee API base URL. Options are
+// applied in order, so whichever of WithBaseURL and WithEnvironment comes
+// last decides the base URL. Useful for pointing at a test server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithEnvironment selects the Yodlee sandbox or production API.
+func WithEnvironment(env Environment) Option {
+	return func(c *Client) {
+		if env == EnvSandbox {
+			c.baseURL = sandboxBaseURL
+		} else {
+			c.baseURL = productionBaseURL
+		}
+	}
+}
+
+// WithHTTPClient overrides the *http.Client used for every request, e.g. to
+// set a timeout or point at a test server's transport.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithLocale sets the locale Yodlee uses to localize responses (e.g.
+// "en_US"), sent as the Accept-Language header on every request.
+func WithLocale(locale string) Option {
+	return func(c *Client) {
+		c.locale = locale
+	}
+}
+
+// WithLogger installs a Logger that receives one line per outgoing request.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithUserAgent overrides the default User-Agent header sent on every
+// request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithSessionRefreshSkew changes how far ahead of expiry Session and
+// UserSession.Token proactively re-authenticate, instead of the 2 minute
+// default.
+func WithSessionRefreshSkew(skew time.Duration) Option {
+	return func(c *Client) {
+		c.refreshSkew = skew
+	}
+}
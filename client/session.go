@@ -0,0 +1,200 @@
+package yodlee
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/tkrajina/yodlee-client/internal/genclient"
+)
+
+// Yodlee's documented session lifetimes: cobrand sessions last ~100 minutes,
+// user sessions expire after ~30 minutes of inactivity.
+const (
+	cobSessionTTL  = 100 * time.Minute
+	userSessionTTL = 30 * time.Minute
+)
+
+// invalidSessionErrorCodes are the Yodlee error codes that mean "your
+// session token is no longer valid", as opposed to any other failure.
+var invalidSessionErrorCodes = []string{"Y002", "Y007"}
+
+// sessionState tracks a single Yodlee session token and its expiry, guarded
+// by a mutex so concurrent callers can share and refresh it safely.
+type sessionState struct {
+	mu       sync.RWMutex
+	token    string
+	issuedAt time.Time
+	ttl      time.Duration
+}
+
+func (s *sessionState) get() (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.token, s.token != ""
+}
+
+// expired reports whether the session is unset or within skew of expiring.
+func (s *sessionState) expired(skew time.Duration) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.token == "" {
+		return true
+	}
+	return time.Since(s.issuedAt) >= s.ttl-skew
+}
+
+func (s *sessionState) set(token string, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+	s.issuedAt = time.Now()
+	s.ttl = ttl
+}
+
+func (s *sessionState) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = ""
+}
+
+// Session returns a valid cobrand session token, transparently
+// re-authenticating with Yodlee when the current one is within
+// WithSessionRefreshSkew of expiring.
+func (c *Client) Session(ctx context.Context) (string, error) {
+	if token, ok := c.cob.get(); ok && !c.cob.expired(c.refreshSkew) {
+		return token, nil
+	}
+	return c.refreshCobSession(ctx)
+}
+
+func (c *Client) refreshCobSession(ctx context.Context) (string, error) {
+	token, err := c.cobLogin(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.cob.set(token, cobSessionTTL)
+	return token, nil
+}
+
+func (c *Client) cobLogin(ctx context.Context) (string, error) {
+	resp, raw, status, err := c.gen.CobLogin(ctx, genclient.CobLoginRequest{
+		CobrandLogin:    c.Login,
+		CobrandPassword: c.Password,
+	})
+	if err := yodleeError(raw, status, err); err != nil {
+		return "", err
+	}
+	return resp.CobrandConversationCredentials.SessionToken, nil
+}
+
+// Close logs the cobrand session out of Yodlee. It's a no-op if the client
+// never authenticated.
+func (c *Client) Close(ctx context.Context) error {
+	token, ok := c.cob.get()
+	if !ok {
+		return nil
+	}
+	c.cob.clear()
+	_, raw, status, err := c.gen.Logout(ctx, genclient.SessionRequest{CobSessionToken: token})
+	return yodleeError(raw, status, err)
+}
+
+// UserSession is a Yodlee user session obtained from GetUserSessionToken. It
+// re-authenticates transparently the same way the cobrand session does, so
+// callers can hold onto it across a long-running job instead of re-deriving
+// a bare token themselves.
+type UserSession struct {
+	client   *Client
+	login    string
+	password string
+	state    sessionState
+}
+
+// Token returns a valid user session token, refreshing it if necessary.
+func (s *UserSession) Token(ctx context.Context) (string, error) {
+	if token, ok := s.state.get(); ok && !s.state.expired(s.client.refreshSkew) {
+		return token, nil
+	}
+	return s.refresh(ctx)
+}
+
+func (s *UserSession) refresh(ctx context.Context) (string, error) {
+	cobToken, err := s.client.Session(ctx)
+	if err != nil {
+		return "", err
+	}
+	resp, raw, status, err := s.client.gen.UserLogin(ctx, genclient.UserLoginRequest{
+		Login:           s.login,
+		Password:        s.password,
+		CobSessionToken: cobToken,
+	})
+	if err := yodleeError(raw, status, err); err != nil {
+		return "", err
+	}
+	token := resp.UserContext.ConversationCredentials.SessionToken
+	s.state.set(token, userSessionTTL)
+	return token, nil
+}
+
+// Close logs the user session out of Yodlee. It's a no-op if the session
+// was never established.
+func (s *UserSession) Close(ctx context.Context) error {
+	token, ok := s.state.get()
+	if !ok {
+		return nil
+	}
+	s.state.clear()
+	cobToken, _ := s.client.cob.get()
+	_, raw, status, err := s.client.gen.Logout(ctx, genclient.SessionRequest{
+		CobSessionToken:  cobToken,
+		UserSessionToken: token,
+	})
+	return yodleeError(raw, status, err)
+}
+
+// withSessionRetry runs call with the current cobrand session token and, if
+// session is non-nil, the current user session token. If Yodlee rejects the
+// call with an invalid-session error (Y002/Y007), it can't tell from the
+// error alone whether the cobrand or the user session was the one that
+// expired, so it forces a refresh of both before retrying call exactly once.
+func (c *Client) withSessionRetry(ctx context.Context, session *UserSession, call func(cobToken, userToken string) error) error {
+	cobToken, err := c.Session(ctx)
+	if err != nil {
+		return err
+	}
+	var userToken string
+	if session != nil {
+		userToken, err = session.Token(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	err = call(cobToken, userToken)
+	if !isInvalidSessionError(err) {
+		return err
+	}
+	cobToken, cobErr := c.refreshCobSession(ctx)
+	var userErr error
+	if session != nil {
+		userToken, userErr = session.refresh(ctx)
+	}
+	if cobErr != nil || userErr != nil {
+		return errors.Join(err, cobErr, userErr)
+	}
+	return call(cobToken, userToken)
+}
+
+func isInvalidSessionError(err error) bool {
+	var yerr *Error
+	if !errors.As(err, &yerr) {
+		return false
+	}
+	for _, code := range invalidSessionErrorCodes {
+		if yerr.Code == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package yodlee
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/tkrajina/yodlee-client/internal/genclient"
+)
+
+// Transaction is a single transaction returned by GetTransactions,
+// TransactionsIter or AllTransactions.
+type Transaction = genclient.Transaction
+
+// maxTransactionPageSize is the largest window Yodlee's
+// executeUserSearchRequest will return in a single call.
+const maxTransactionPageSize = 500
+
+// TransactionIterOption configures a TransactionIterator created by
+// TransactionsIter or AllTransactions.
+type TransactionIterOption func(*TransactionIterator)
+
+// WithTransactionPageSize overrides the number of transactions fetched per
+// underlying call, instead of Yodlee's 500-per-call maximum. Values outside
+// (0, 500] are ignored and the default is kept.
+func WithTransactionPageSize(size int) TransactionIterOption {
+	return func(it *TransactionIterator) {
+		if size > 0 && size <= maxTransactionPageSize {
+			it.pageSize = size
+		}
+	}
+}
+
+// TransactionsIter returns an iterator over every transaction matching
+// filter, fetching pages of up to 500 (or WithTransactionPageSize) behind
+// the scenes as Next is called. The iterator works on a copy of filter,
+// whose StartNumber, EndNumber, LowerFetchLimit and HigherFetchLimit it
+// overwrites to drive the paging; filter itself is left untouched, so the
+// same *GetTransactionInput can be reused for unrelated calls.
+func (c *Client) TransactionsIter(ctx context.Context, session *UserSession, filter *GetTransactionInput, opts ...TransactionIterOption) *TransactionIterator {
+	f := *filter
+	it := &TransactionIterator{ctx: ctx, client: c, session: session, filter: f, pageSize: maxTransactionPageSize}
+	for _, opt := range opts {
+		opt(it)
+	}
+	return it
+}
+
+// TransactionIterator walks a transaction search result page by page. Use it
+// like:
+//
+//	it := client.TransactionsIter(ctx, session, input)
+//	for it.Next() {
+//	    tx := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//	    // handle err
+//	}
+type TransactionIterator struct {
+	ctx     context.Context
+	client  *Client
+	session *UserSession
+	filter  GetTransactionInput
+
+	pageSize int
+
+	buf     []Transaction
+	pos     int
+	fetched int
+	total   int
+	started bool
+	done    bool
+	err     error
+}
+
+// Next advances the iterator and reports whether a Value is available. It
+// returns false once every transaction has been visited or an error occurs.
+func (it *TransactionIterator) Next() bool {
+	if it.err != nil || it.done {
+		return false
+	}
+	it.pos++
+	if it.pos < len(it.buf) {
+		return true
+	}
+	if it.started && it.fetched >= it.total {
+		it.done = true
+		return false
+	}
+	if err := it.fetchPage(); err != nil {
+		it.err = err
+		return false
+	}
+	if len(it.buf) == 0 {
+		it.done = true
+		return false
+	}
+	it.pos = 0
+	return true
+}
+
+// Value returns the transaction at the iterator's current position. It's
+// only valid to call after a call to Next that returned true.
+func (it *TransactionIterator) Value() *Transaction {
+	return &it.buf[it.pos]
+}
+
+// Err returns the first error encountered while fetching, if any.
+func (it *TransactionIterator) Err() error {
+	return it.err
+}
+
+func (it *TransactionIterator) fetchPage() error {
+	it.filter.StartNumber = it.fetched + 1
+	it.filter.EndNumber = it.filter.StartNumber + it.pageSize - 1
+	// transactionSearchRequest.higherFetchLimit/lowerFetchLimit cap the
+	// overall window of transactions Yodlee considers before applying
+	// resultRange; leaving them at NewGetTransactionInput's defaults
+	// (1/500) would make every page past the first fall outside that
+	// cap and come back empty, silently truncating AllTransactions and
+	// TransactionsIter to the first page.
+	it.filter.LowerFetchLimit = strconv.Itoa(it.filter.StartNumber)
+	it.filter.HigherFetchLimit = strconv.Itoa(it.filter.EndNumber)
+	output, err := it.client.GetTransactions(it.ctx, it.session, &it.filter)
+	if err != nil {
+		return err
+	}
+	it.total = output.NumberOfHits
+	it.buf = output.SearchResult.Transactions
+	it.fetched += len(it.buf)
+	it.started = true
+	return nil
+}
+
+// AllTransactions fetches every transaction matching filter, paging through
+// Yodlee's 500-per-call limit automatically. For large result sets,
+// TransactionsIter avoids holding everything in memory at once.
+func (c *Client) AllTransactions(ctx context.Context, session *UserSession, filter *GetTransactionInput, opts ...TransactionIterOption) ([]*Transaction, error) {
+	it := c.TransactionsIter(ctx, session, filter, opts...)
+	var out []*Transaction
+	for it.Next() {
+		out = append(out, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
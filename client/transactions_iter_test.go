@@ -0,0 +1,120 @@
+package yodlee
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newTransactionTestServer serves executeUserSearchRequest against a fixed
+// total number of transactions, honoring the request's resultRange so
+// pagination can be exercised end to end. It fails the test if a page's
+// higherFetchLimit/lowerFetchLimit don't cover its resultRange, catching a
+// regression of the fetch-limit bug fetchPage works around.
+func newTransactionTestServer(t *testing.T, total int) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/authenticate/coblogin", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"cobrandConversationCredentials": map[string]string{"sessionToken": "cob-token"},
+		})
+	})
+	mux.HandleFunc("/authenticate/login", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"userContext": map[string]interface{}{
+				"conversationCredentials": map[string]string{"sessionToken": "user-token"},
+			},
+		})
+	})
+	mux.HandleFunc("/jsonsdk/TransactionSearchService/executeUserSearchRequest", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("ParseForm: %v", err)
+		}
+		start, _ := strconv.Atoi(r.Form.Get("transactionSearchRequest.resultRange.startNumber"))
+		end, _ := strconv.Atoi(r.Form.Get("transactionSearchRequest.resultRange.endNumber"))
+		lower, _ := strconv.Atoi(r.Form.Get("transactionSearchRequest.lowerFetchLimit"))
+		higher, _ := strconv.Atoi(r.Form.Get("transactionSearchRequest.higherFetchLimit"))
+		if lower > start || higher < end {
+			t.Errorf("page [%d,%d] not covered by fetch limit [%d,%d]", start, end, lower, higher)
+		}
+
+		if end > total {
+			end = total
+		}
+		var txs []string
+		for id := start; id <= end; id++ {
+			txs = append(txs, fmt.Sprintf(`{"viewKey":{"transactionId":%d}}`, id))
+		}
+		fmt.Fprintf(w, `{"numberOfHits":%d,"searchResult":{"transactions":[%s]}}`, total, strings.Join(txs, ","))
+	})
+	return httptest.NewServer(mux)
+}
+
+func transactionIDs(t *testing.T, txs []*Transaction) []int {
+	t.Helper()
+	ids := make([]int, len(txs))
+	for i, tx := range txs {
+		ids[i] = tx.ViewKey.TransactionID
+	}
+	return ids
+}
+
+func wantIDs(n int) []int {
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	return ids
+}
+
+func TestAllTransactions_Pagination(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int
+		pageSize int
+	}{
+		{"single page", 10, 500},
+		{"exact multiple of page size", 10, 5},
+		{"partial last page", 12, 5},
+		{"zero results", 0, 500},
+		{"more than 500 results", 1200, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTransactionTestServer(t, tt.total)
+			defer ts.Close()
+
+			c := New("login", "password", WithBaseURL(ts.URL))
+			ctx := context.Background()
+			session, err := c.GetUserSessionToken(ctx, "user", "pass")
+			if err != nil {
+				t.Fatalf("GetUserSessionToken: %v", err)
+			}
+
+			txs, err := c.AllTransactions(ctx, session, NewGetTransactionInput(), WithTransactionPageSize(tt.pageSize))
+			if err != nil {
+				t.Fatalf("AllTransactions: %v", err)
+			}
+			if got, want := transactionIDs(t, txs), wantIDs(tt.total); !equalInts(got, want) {
+				t.Errorf("AllTransactions = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
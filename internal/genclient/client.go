@@ -0,0 +1,208 @@
+package genclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// HttpRequestDoer performs HTTP requests, as implemented by *http.Client.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// RequestEditorFn can be used to modify outgoing requests, e.g. to add
+// headers or query parameters.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ClientOption configures a Client at construction time.
+type ClientOption func(*Client) error
+
+// WithHTTPClient overrides the default http.Client used to send requests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn registers a function to mutate every outgoing request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// Client is the low-level client for the Yodlee srest/restserver v1.0 API
+// described by openapi/yodlee.yaml.
+type Client struct {
+	Server         string
+	Client         HttpRequestDoer
+	RequestEditors []RequestEditorFn
+}
+
+// NewClient creates a Client pointed at server, applying any opts.
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	c := &Client{Server: server}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	if c.Client == nil {
+		c.Client = http.DefaultClient
+	}
+	return c, nil
+}
+
+// CobLogin calls POST /authenticate/coblogin. It returns the raw response
+// body and status code alongside the decoded JSON so that callers who need
+// to inspect Yodlee's out-of-band error envelope (not modeled in the
+// OpenAPI schema) can do so before trusting the decoded value.
+func (c *Client) CobLogin(ctx context.Context, body CobLoginRequest) (*CobLoginResponse, []byte, int, error) {
+	var out CobLoginResponse
+	raw, status, err := c.post(ctx, "/authenticate/coblogin", body, &out)
+	if err != nil {
+		return nil, raw, status, err
+	}
+	return &out, raw, status, nil
+}
+
+// UserLogin calls POST /authenticate/login.
+func (c *Client) UserLogin(ctx context.Context, body UserLoginRequest) (*UserLoginResponse, []byte, int, error) {
+	var out UserLoginResponse
+	raw, status, err := c.post(ctx, "/authenticate/login", body, &out)
+	if err != nil {
+		return nil, raw, status, err
+	}
+	return &out, raw, status, nil
+}
+
+// Logout calls POST /authenticate/logout, invalidating a cobrand and/or
+// user session token. Leave body.UserSessionToken empty to log out only
+// the cobrand session.
+func (c *Client) Logout(ctx context.Context, body SessionRequest) (*LogoutResponse, []byte, int, error) {
+	var out LogoutResponse
+	raw, status, err := c.post(ctx, "/authenticate/logout", body, &out)
+	if err != nil {
+		return nil, raw, status, err
+	}
+	return &out, raw, status, nil
+}
+
+// GetAllSiteAccounts calls POST /jsonsdk/SiteAccountManagement/getAllSiteAccounts.
+func (c *Client) GetAllSiteAccounts(ctx context.Context, body SessionRequest) ([]*SiteAccount, []byte, int, error) {
+	var out []*SiteAccount
+	raw, status, err := c.post(ctx, "/jsonsdk/SiteAccountManagement/getAllSiteAccounts", body, &out)
+	if err != nil {
+		return nil, raw, status, err
+	}
+	return out, raw, status, nil
+}
+
+// ExecuteUserSearchRequest calls POST /jsonsdk/TransactionSearchService/executeUserSearchRequest.
+func (c *Client) ExecuteUserSearchRequest(ctx context.Context, body TransactionSearchRequest) (*TransactionSearchResponse, []byte, int, error) {
+	var out TransactionSearchResponse
+	raw, status, err := c.post(ctx, "/jsonsdk/TransactionSearchService/executeUserSearchRequest", body, &out)
+	if err != nil {
+		return nil, raw, status, err
+	}
+	return &out, raw, status, nil
+}
+
+// Register3 calls POST /jsonsdk/UserRegistration/register3.
+func (c *Client) Register3(ctx context.Context, body RegisterRequest) (*RegisterResponse, []byte, int, error) {
+	var out RegisterResponse
+	raw, status, err := c.post(ctx, "/jsonsdk/UserRegistration/register3", body, &out)
+	if err != nil {
+		return nil, raw, status, err
+	}
+	return &out, raw, status, nil
+}
+
+// GetHoldingsSummary calls POST /jsonsdk/Holdings/getHoldingsSummary.
+func (c *Client) GetHoldingsSummary(ctx context.Context, body SessionRequest) (*HoldingsSummary, []byte, int, error) {
+	var out HoldingsSummary
+	raw, status, err := c.post(ctx, "/jsonsdk/Holdings/getHoldingsSummary", body, &out)
+	if err != nil {
+		return nil, raw, status, err
+	}
+	return &out, raw, status, nil
+}
+
+// GetStatements calls POST /jsonsdk/Statements/getStatements.
+func (c *Client) GetStatements(ctx context.Context, body SessionRequest) (*StatementsResponse, []byte, int, error) {
+	var out StatementsResponse
+	raw, status, err := c.post(ctx, "/jsonsdk/Statements/getStatements", body, &out)
+	if err != nil {
+		return nil, raw, status, err
+	}
+	return &out, raw, status, nil
+}
+
+// post sends body as an application/x-www-form-urlencoded POST, as required
+// by Yodlee's srest/restserver endpoints, and decodes the JSON response
+// into out. It returns the raw response body and HTTP status code even on
+// a decode error, so callers can fall back to inspecting them themselves.
+func (c *Client) post(ctx context.Context, path string, body interface{}, out interface{}) ([]byte, int, error) {
+	form, err := toForm(body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("genclient: encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Server+path, bytes.NewReader([]byte(form.Encode())))
+	if err != nil {
+		return nil, 0, fmt.Errorf("genclient: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for _, edit := range c.RequestEditors {
+		if err := edit(ctx, req); err != nil {
+			return nil, 0, fmt.Errorf("genclient: edit request: %w", err)
+		}
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("genclient: do request: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, fmt.Errorf("genclient: read response: %w", err)
+	}
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return respBody, resp.StatusCode, fmt.Errorf("genclient: decode response: %w", err)
+		}
+	}
+	return respBody, resp.StatusCode, nil
+}
+
+// toForm flattens a request struct's JSON representation into form values,
+// using its `json` tags as field names.
+//
+// Decoding is done with UseNumber so that json.Number (itself a string)
+// round-trips through fmt.Sprintf("%v", v) unchanged. Without it, numbers
+// land as float64 and %v's default %g formatting switches to scientific
+// notation above 1e6 (e.g. a transactionSearchRequest.resultRange.startNumber
+// of 1000001 would otherwise be submitted to Yodlee as "1.000001e+06").
+func toForm(body interface{}) (url.Values, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var fields map[string]interface{}
+	if err := dec.Decode(&fields); err != nil {
+		return nil, err
+	}
+	values := url.Values{}
+	for k, v := range fields {
+		values.Set(k, fmt.Sprintf("%v", v))
+	}
+	return values, nil
+}
@@ -0,0 +1,18 @@
+package genclient
+
+import "testing"
+
+func TestToForm(t *testing.T) {
+	form, err := toForm(TransactionSearchRequest{
+		ContainerType: "All",
+		StartNumber:   1000001,
+		EndNumber:     1000500,
+	})
+	if err != nil {
+		t.Fatalf("toForm: %v", err)
+	}
+	const key = "transactionSearchRequest.resultRange.startNumber"
+	if got, want := form.Get(key), "1000001"; got != want {
+		t.Errorf("%s = %q, want %q", key, got, want)
+	}
+}
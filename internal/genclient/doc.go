@@ -0,0 +1,18 @@
+// Package genclient is the low-level Yodlee API client.
+//
+// Its types (types.gen.go) are generated from openapi/yodlee.yaml, the
+// vendored slice of Yodlee's published spec, by oapi-codegen; run `go
+// generate ./...` after editing the spec to pick up new fields or
+// endpoints. The transport in client.go stays hand-written, because
+// several of Yodlee's response shapes (a JSON array on success,
+// out-of-band error envelopes not expressible in the OpenAPI schema,
+// form-encoded request bodies) need more care than generated request
+// code accounts for. The spec itself only covers the endpoints this
+// client's callers currently need; bills, cashflow, verification,
+// provider accounts and the rest of Yodlee's surface aren't modeled,
+// and extending the spec (components.schemas + paths) is how to pick up
+// more of it. Package yodlee (client/client.go) wraps this package with
+// a more ergonomic API.
+//
+//go:generate go run github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen@v2.1.0 --config=oapi-codegen.yaml -o types.gen.go ../../openapi/yodlee.yaml
+package genclient